@@ -0,0 +1,100 @@
+// Command opsys-bench generates synthetic workloads and runs every
+// scheduling algorithm in pkg/sched against each one, printing the
+// avgWait/avgTat/throughput/cpuUtil/respVar comparison table that
+// workload.Benchmark produces.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"opsys/pkg/sched"
+	"opsys/pkg/workload"
+)
+
+func main() {
+	n := flag.Int("n", 50, "jobs per workload")
+	workloads := flag.Int("workloads", 3, "number of independently seeded workloads to generate")
+	arrivalRate := flag.Float64("arrival-rate", 0.5, "expected arrivals per tick (Poisson lambda)")
+	burstDist := flag.String("burst-dist", "exponential", "burst distribution: exponential, pareto")
+	burstMean := flag.Float64("burst-mean", 6, "mean burst for exponential, scale for pareto")
+	paretoShape := flag.Float64("pareto-shape", 2, "pareto shape parameter (alpha)")
+	minPriority := flag.Int("min-priority", 0, "minimum priority (lower runs first)")
+	maxPriority := flag.Int("max-priority", 4, "maximum priority")
+	seed := flag.Int64("seed", 1, "seed for the first workload; later workloads use seed+i")
+	quantum := flag.Int("quantum", 4, "time quantum for rr and the top MLFQ level")
+	agingRate := flag.Int("aging-rate", 1, "priority improvement per aging-interval for priority-p")
+	agingInterval := flag.Int("aging-interval", 5, "ticks between aging bumps for priority-p")
+	boostInterval := flag.Int("boost-interval", 0, "ticks between MLFQ priority boosts; 0 disables boosting")
+	algos := flag.String("algos", "fcfs,sjf,srtf,priority-np,priority-p,rr,mlfq", "comma-separated algorithms to compare")
+	flag.Parse()
+
+	dist := workload.Exponential
+	if *burstDist == "pareto" {
+		dist = workload.Pareto
+	}
+
+	var sets [][]sched.Schedulable
+	for i := 0; i < *workloads; i++ {
+		sets = append(sets, workload.Generate(workload.Config{
+			N:           *n,
+			ArrivalRate: *arrivalRate,
+			BurstDist:   dist,
+			BurstMean:   *burstMean,
+			ParetoShape: *paretoShape,
+			MinPriority: *minPriority,
+			MaxPriority: *maxPriority,
+			Seed:        *seed + int64(i),
+		}))
+	}
+
+	fns, err := algoFns(strings.Split(*algos, ","), *quantum, *agingRate, *agingInterval, *boostInterval)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	report := workload.Benchmark(fns, sets)
+	fmt.Print(report.String())
+}
+
+func algoFns(names []string, quantum, agingRate, agingInterval, boostInterval int) ([]workload.SchedulerFn, error) {
+	fns := make([]workload.SchedulerFn, 0, len(names))
+	for _, name := range names {
+		name = strings.TrimSpace(name)
+		switch name {
+		case "fcfs":
+			fns = append(fns, workload.SchedulerFn{Name: name, Run: sched.FCFS})
+		case "sjf":
+			fns = append(fns, workload.SchedulerFn{Name: name, Run: sched.SJF})
+		case "srtf":
+			fns = append(fns, workload.SchedulerFn{Name: name, Run: sched.SRTF})
+		case "priority-np":
+			fns = append(fns, workload.SchedulerFn{Name: name, Run: sched.PriorityNP})
+		case "priority-p":
+			fns = append(fns, workload.SchedulerFn{Name: name, Run: func(items []sched.Schedulable) ([]sched.Segment, []string) {
+				return sched.PriorityP(items, agingRate, agingInterval)
+			}})
+		case "rr":
+			fns = append(fns, workload.SchedulerFn{Name: name, Run: func(items []sched.Schedulable) ([]sched.Segment, []string) {
+				return sched.RoundRobin(items, quantum)
+			}})
+		case "mlfq":
+			fns = append(fns, workload.SchedulerFn{Name: name, Run: func(items []sched.Schedulable) ([]sched.Segment, []string) {
+				return sched.MLFQ(items, sched.MLFQConfig{
+					Queues: []sched.QueueConfig{
+						{Quantum: quantum, RR: true},
+						{Quantum: quantum * 2, RR: true},
+						{RR: false},
+					},
+					BoostInterval: boostInterval,
+				})
+			}})
+		default:
+			return nil, fmt.Errorf("unknown algorithm %q", name)
+		}
+	}
+	return fns, nil
+}
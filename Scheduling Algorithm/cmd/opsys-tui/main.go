@@ -0,0 +1,324 @@
+// Command opsys-tui drives FCFS/SJF/SRTF one tick at a time inside a
+// termbox screen: the Gantt chart grows cell by cell, the ready queue and
+// running PCB are shown live, and a command line lets you step through
+// or steer the simulation instead of only seeing the final result.
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	termbox "github.com/nsf/termbox-go"
+
+	"opsys/pkg/sched"
+)
+
+type procSpec struct {
+	name     string
+	arrival  int
+	burst    int
+	priority int
+}
+
+type app struct {
+	algo  string
+	specs []procSpec
+	items []sched.Schedulable
+	eng   sched.Scheduler
+	now   int
+	gantt []sched.Segment
+	log   []string
+
+	input   []rune
+	cursor  int
+	history []string
+	histPos int
+
+	runLeft int
+	quit    bool
+}
+
+func newApp(algo string) *app {
+	a := &app{algo: algo}
+	a.resetScheduler()
+	return a
+}
+
+// resetScheduler rebuilds a fresh Scheduler of the current algorithm and
+// replays every known process spec into it from scratch, so "reset" and
+// "algo" both restart the whole simulation.
+func (a *app) resetScheduler() {
+	switch a.algo {
+	case "sjf":
+		a.eng = sched.NewSJFScheduler()
+	case "srtf":
+		a.eng = sched.NewSRTFScheduler()
+	default:
+		a.algo = "fcfs"
+		a.eng = sched.NewFCFSScheduler()
+	}
+	a.now = 0
+	a.gantt = nil
+	a.items = make([]sched.Schedulable, 0, len(a.specs))
+	for i, sp := range a.specs {
+		p := sched.NewPcb(i+1, sp.name, sp.arrival, sp.burst)
+		p.SetPriority(sp.priority)
+		a.items = append(a.items, p)
+		a.eng.Enqueue(p)
+	}
+}
+
+func (a *app) done() bool {
+	if len(a.items) == 0 {
+		return true
+	}
+	for _, it := range a.items {
+		if it.Finish() == -1 {
+			return false
+		}
+	}
+	return true
+}
+
+func (a *app) step() {
+	if a.done() {
+		return
+	}
+	if seg, ok := a.eng.Tick(a.now); ok {
+		a.gantt = append(a.gantt, seg)
+	}
+	a.now++
+}
+
+func (a *app) currentName() string {
+	if len(a.gantt) == 0 {
+		return ""
+	}
+	last := a.gantt[len(a.gantt)-1]
+	if last.End == a.now {
+		return last.Name
+	}
+	return ""
+}
+
+func (a *app) readyNames() []string {
+	running := a.currentName()
+	var names []string
+	for _, it := range a.items {
+		if it.Arrival() <= a.now && it.Finish() == -1 && it.Name() != running {
+			names = append(names, it.Name())
+		}
+	}
+	return names
+}
+
+func (a *app) exec(line string) string {
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return ""
+	}
+	switch fields[0] {
+	case "step":
+		a.step()
+		return fmt.Sprintf("t=%d", a.now)
+	case "run":
+		n := 1
+		if len(fields) > 1 {
+			if v, err := strconv.Atoi(fields[1]); err == nil {
+				n = v
+			}
+		}
+		a.runLeft = n
+		return fmt.Sprintf("running %d tick(s)", n)
+	case "pause":
+		a.runLeft = 0
+		return "paused"
+	case "reset":
+		a.resetScheduler()
+		return "reset"
+	case "algo":
+		if len(fields) < 2 {
+			return "usage: algo {fcfs|sjf|srtf}"
+		}
+		a.algo = fields[1]
+		a.resetScheduler()
+		return "switched to " + a.algo + " (reset)"
+	case "add":
+		if len(fields) < 4 {
+			return "usage: add <name> <arrival> <burst> [priority]"
+		}
+		arrival, err1 := strconv.Atoi(fields[2])
+		burst, err2 := strconv.Atoi(fields[3])
+		if err1 != nil || err2 != nil {
+			return "arrival and burst must be integers"
+		}
+		priority := 0
+		if len(fields) > 4 {
+			if v, err := strconv.Atoi(fields[4]); err == nil {
+				priority = v
+			}
+		}
+		sp := procSpec{name: fields[1], arrival: arrival, burst: burst, priority: priority}
+		a.specs = append(a.specs, sp)
+		p := sched.NewPcb(len(a.specs), sp.name, sp.arrival, sp.burst)
+		p.SetPriority(sp.priority)
+		a.items = append(a.items, p)
+		a.eng.Enqueue(p)
+		return "added " + p.Name()
+	case "dump":
+		var sb strings.Builder
+		for _, it := range a.items {
+			fmt.Fprintf(&sb, "%s arrival=%d burst=%d start=%d finish=%d wait=%d tat=%d", it.Name(), it.Arrival(), it.Burst(), it.Start(), it.Finish(), it.Wait(), it.Tat())
+			sb.WriteByte('\n')
+		}
+		return sb.String()
+	default:
+		return "unknown command: " + fields[0]
+	}
+}
+
+func drawText(x, y int, s string, fg, bg termbox.Attribute) {
+	for i, r := range s {
+		termbox.SetCell(x+i, y, r, fg, bg)
+	}
+}
+
+func (a *app) drawGantt(y int) {
+	x := 0
+	for _, s := range a.gantt {
+		width := s.End - s.Start
+		if width < 1 {
+			width = 1
+		}
+		cell := fmt.Sprintf("[%-*s]", width*2, s.Name)
+		drawText(x, y, cell, termbox.ColorBlack, termbox.ColorCyan)
+		x += len(cell)
+	}
+}
+
+func (a *app) render() {
+	termbox.Clear(termbox.ColorDefault, termbox.ColorDefault)
+	_, h := termbox.Size()
+
+	drawText(0, 0, fmt.Sprintf("opsys-tui  algo=%s  t=%d  (step / run N / add name arr burst [prio] / algo X / pause / reset / dump, Esc quits)", a.algo, a.now), termbox.ColorWhite, termbox.ColorDefault)
+	a.drawGantt(2)
+
+	drawText(0, 4, "running: "+a.currentName(), termbox.ColorGreen, termbox.ColorDefault)
+	drawText(0, 5, "ready:   "+strings.Join(a.readyNames(), ", "), termbox.ColorYellow, termbox.ColorDefault)
+
+	logTop := 7
+	for i, line := range a.log {
+		if logTop+i >= h-2 {
+			break
+		}
+		drawText(0, logTop+i, line, termbox.ColorCyan, termbox.ColorDefault)
+	}
+
+	drawText(0, h-1, "> "+string(a.input), termbox.ColorWhite, termbox.ColorDefault)
+	termbox.SetCursor(2+a.cursor, h-1)
+	termbox.Flush()
+}
+
+func (a *app) appendLog(out string) {
+	if out == "" {
+		return
+	}
+	for _, line := range strings.Split(strings.TrimRight(out, "\n"), "\n") {
+		a.log = append(a.log, line)
+	}
+	if len(a.log) > 200 {
+		a.log = a.log[len(a.log)-200:]
+	}
+}
+
+func (a *app) handleKey(ev termbox.Event) {
+	switch ev.Key {
+	case termbox.KeyEsc, termbox.KeyCtrlC:
+		a.quit = true
+	case termbox.KeyEnter:
+		line := string(a.input)
+		a.history = append(a.history, line)
+		a.histPos = len(a.history)
+		a.appendLog("> " + line)
+		a.appendLog(a.exec(line))
+		a.input = nil
+		a.cursor = 0
+	case termbox.KeyBackspace, termbox.KeyBackspace2:
+		if a.cursor > 0 {
+			a.input = append(a.input[:a.cursor-1], a.input[a.cursor:]...)
+			a.cursor--
+		}
+	case termbox.KeyDelete:
+		if a.cursor < len(a.input) {
+			a.input = append(a.input[:a.cursor], a.input[a.cursor+1:]...)
+		}
+	case termbox.KeyArrowLeft:
+		if a.cursor > 0 {
+			a.cursor--
+		}
+	case termbox.KeyArrowRight:
+		if a.cursor < len(a.input) {
+			a.cursor++
+		}
+	case termbox.KeyArrowUp:
+		if a.histPos > 0 {
+			a.histPos--
+			a.input = []rune(a.history[a.histPos])
+			a.cursor = len(a.input)
+		}
+	case termbox.KeyArrowDown:
+		if a.histPos < len(a.history)-1 {
+			a.histPos++
+			a.input = []rune(a.history[a.histPos])
+			a.cursor = len(a.input)
+		} else {
+			a.histPos = len(a.history)
+			a.input = nil
+			a.cursor = 0
+		}
+	case termbox.KeySpace:
+		a.input = append(a.input[:a.cursor], append([]rune{' '}, a.input[a.cursor:]...)...)
+		a.cursor++
+	default:
+		if ev.Ch != 0 {
+			a.input = append(a.input[:a.cursor], append([]rune{ev.Ch}, a.input[a.cursor:]...)...)
+			a.cursor++
+		}
+	}
+}
+
+func main() {
+	if err := termbox.Init(); err != nil {
+		panic(err)
+	}
+	defer termbox.Close()
+
+	a := newApp("fcfs")
+	events := make(chan termbox.Event)
+	go func() {
+		for {
+			events <- termbox.PollEvent()
+		}
+	}()
+
+	ticker := time.NewTicker(150 * time.Millisecond)
+	defer ticker.Stop()
+
+	a.render()
+	for !a.quit {
+		select {
+		case ev := <-events:
+			if ev.Type == termbox.EventKey {
+				a.handleKey(ev)
+			}
+		case <-ticker.C:
+			if a.runLeft > 0 {
+				a.step()
+				a.runLeft--
+			}
+		}
+		a.render()
+	}
+}
@@ -0,0 +1,161 @@
+// Package prque provides a generic lazy priority queue: a priority queue
+// for items whose priority keeps drifting over time (e.g. via aging),
+// where re-sorting the whole set on every pop would be wasteful.
+package prque
+
+import "container/heap"
+
+// Ordered matches the element types a LazyQueue can be keyed by.
+type Ordered interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64 | ~string
+}
+
+// Priority returns an item's true priority at tick now.
+type Priority[P Ordered] func(now int) P
+
+// MaxPriority returns the best priority an item can possibly reach by
+// tick until; it is used as a cheap, temporarily-stale sort key so a
+// LazyQueue doesn't need to evaluate Priority for every item on every Pop.
+type MaxPriority[P Ordered] func(until int) P
+
+// Element is the handle returned by Push. Keep it to call Update when an
+// item's true priority changes before its next scheduled Refresh (e.g.
+// SRTF's remaining time shrinking after a run).
+type Element[P Ordered, V any] struct {
+	Value       V
+	priority    Priority[P]
+	maxPriority MaxPriority[P]
+	est         P
+	heap        int
+	index       int
+}
+
+type elemHeap[P Ordered, V any] []*Element[P, V]
+
+func (h elemHeap[P, V]) Len() int           { return len(h) }
+func (h elemHeap[P, V]) Less(i, j int) bool { return h[i].est < h[j].est }
+func (h elemHeap[P, V]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index, h[j].index = i, j
+}
+func (h *elemHeap[P, V]) Push(x any) {
+	e := x.(*Element[P, V])
+	e.index = len(*h)
+	*h = append(*h, e)
+}
+func (h *elemHeap[P, V]) Pop() any {
+	old := *h
+	n := len(old)
+	e := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return e
+}
+
+// LazyQueue holds two internal heaps, each ordered by an item's estimated
+// MaxPriority valid until that half's next Refresh. Pop only needs to
+// resolve the true Priority of the two heap heads, never the whole set;
+// Refresh rebuilds one half at a time, so a full O(n) rebuild is
+// amortized across many ticks instead of paid up front on every change.
+type LazyQueue[P Ordered, V any] struct {
+	heaps   [2]elemHeap[P, V]
+	refresh int
+	horizon int
+}
+
+// NewLazyQueue creates a LazyQueue whose MaxPriority estimates are valid
+// for about `horizon` ticks before Refresh should be called again.
+func NewLazyQueue[P Ordered, V any](horizon int) *LazyQueue[P, V] {
+	if horizon <= 0 {
+		horizon = 1
+	}
+	return &LazyQueue[P, V]{horizon: horizon}
+}
+
+// Len reports the number of items across both halves.
+func (q *LazyQueue[P, V]) Len() int { return len(q.heaps[0]) + len(q.heaps[1]) }
+
+// Push adds value to whichever half is smaller, estimating its priority
+// will hold until now+2*horizon.
+func (q *LazyQueue[P, V]) Push(now int, value V, priority Priority[P], maxPriority MaxPriority[P]) *Element[P, V] {
+	h := 0
+	if len(q.heaps[1]) < len(q.heaps[0]) {
+		h = 1
+	}
+	e := &Element[P, V]{Value: value, priority: priority, maxPriority: maxPriority, heap: h}
+	e.est = maxPriority(now + 2*q.horizon)
+	heap.Push(&q.heaps[h], e)
+	return e
+}
+
+// Update re-evaluates e's true priority now and re-positions it within
+// its half. Use this instead of a full re-sort whenever a single item's
+// priority changes (e.g. SRTF remaining time after a run).
+func (q *LazyQueue[P, V]) Update(now int, e *Element[P, V]) {
+	e.est = e.priority(now)
+	heap.Fix(&q.heaps[e.heap], e.index)
+}
+
+// Remove deletes e from the queue.
+func (q *LazyQueue[P, V]) Remove(e *Element[P, V]) {
+	heap.Remove(&q.heaps[e.heap], e.index)
+}
+
+// Refresh rebuilds whichever half is next due, recomputing every
+// element's estimated max priority for the next 2*horizon ticks. Call it
+// roughly once per horizon ticks; each call only touches one half.
+func (q *LazyQueue[P, V]) Refresh(now int) {
+	h := q.refresh
+	until := now + 2*q.horizon
+	for _, e := range q.heaps[h] {
+		e.est = e.maxPriority(until)
+	}
+	heap.Init(&q.heaps[h])
+	q.refresh = 1 - h
+}
+
+// Peek returns, without removing it, the element with the best true
+// priority at now.
+func (q *LazyQueue[P, V]) Peek(now int) (*Element[P, V], bool) {
+	switch {
+	case len(q.heaps[0]) == 0 && len(q.heaps[1]) == 0:
+		return nil, false
+	case len(q.heaps[0]) == 0:
+		return q.heaps[1][0], true
+	case len(q.heaps[1]) == 0:
+		return q.heaps[0][0], true
+	}
+	e0, e1 := q.heaps[0][0], q.heaps[1][0]
+	if e0.priority(now) <= e1.priority(now) {
+		return e0, true
+	}
+	return e1, true
+}
+
+// Pop removes and returns the value with the best (lowest) true priority
+// at now. It resolves the true priority of both heap heads, pops the
+// better one, and re-inserts the other — now holding an exact rather than
+// estimated priority — back into its own half.
+func (q *LazyQueue[P, V]) Pop(now int) (V, bool) {
+	var zero V
+	switch {
+	case len(q.heaps[0]) == 0 && len(q.heaps[1]) == 0:
+		return zero, false
+	case len(q.heaps[0]) == 0:
+		return heap.Pop(&q.heaps[1]).(*Element[P, V]).Value, true
+	case len(q.heaps[1]) == 0:
+		return heap.Pop(&q.heaps[0]).(*Element[P, V]).Value, true
+	}
+	e0, e1 := q.heaps[0][0], q.heaps[1][0]
+	p0, p1 := e0.priority(now), e1.priority(now)
+	winner, loser := 0, 1
+	if p1 < p0 {
+		winner, loser = 1, 0
+	}
+	loserHead := q.heaps[loser][0]
+	loserHead.est = loserHead.priority(now)
+	heap.Fix(&q.heaps[loser], loserHead.index)
+	return heap.Pop(&q.heaps[winner]).(*Element[P, V]).Value, true
+}
@@ -0,0 +1,107 @@
+package prque
+
+import "testing"
+
+// TestLazyQueueOrdersByTruePriority checks that Peek/Pop resolve the true,
+// possibly-aged priority of the heap heads rather than trusting the stale
+// estimate a Push or Refresh computed.
+func TestLazyQueueOrdersByTruePriority(t *testing.T) {
+	q := NewLazyQueue[int, string](5)
+
+	// A never ages: always priority 10 (lower wins).
+	q.Push(0, "A",
+		func(now int) int { return 10 },
+		func(until int) int { return 10 },
+	)
+	// B starts worse than A (20) but ages down by 1 per tick, so it
+	// eventually beats A even though its estimate at Push time was stale.
+	q.Push(0, "B",
+		func(now int) int { return 20 - now },
+		func(until int) int { return 20 - until },
+	)
+
+	if got, ok := q.Peek(0); !ok || got.Value != "A" {
+		t.Fatalf("Peek(0) = %v, want A", got)
+	}
+	if got, ok := q.Peek(15); !ok || got.Value != "B" {
+		t.Fatalf("Peek(15) = %v, want B (aged below A)", got)
+	}
+
+	v, ok := q.Pop(15)
+	if !ok || v != "B" {
+		t.Fatalf("Pop(15) = %q, want B", v)
+	}
+	v, ok = q.Pop(15)
+	if !ok || v != "A" {
+		t.Fatalf("Pop(15) = %q, want A", v)
+	}
+	if q.Len() != 0 {
+		t.Fatalf("Len() = %d after draining, want 0", q.Len())
+	}
+}
+
+// TestLazyQueueUpdateAndRemove checks that Update re-positions an element
+// after its priority changes and that Remove takes it out entirely.
+func TestLazyQueueUpdateAndRemove(t *testing.T) {
+	q := NewLazyQueue[int, string](5)
+	priorities := map[string]int{"A": 5, "B": 1}
+	prioFn := func(name string) Priority[int] {
+		return func(now int) int { return priorities[name] }
+	}
+	maxFn := func(name string) MaxPriority[int] {
+		return func(until int) int { return priorities[name] }
+	}
+
+	ea := q.Push(0, "A", prioFn("A"), maxFn("A"))
+	q.Push(0, "B", prioFn("B"), maxFn("B"))
+
+	if v, _ := q.Pop(0); v != "B" {
+		t.Fatalf("Pop(0) = %q, want B (priority 1)", v)
+	}
+	q.Push(0, "B", prioFn("B"), maxFn("B")) // put B back for the next check
+
+	// A improves to beat B.
+	priorities["A"] = 0
+	q.Update(0, ea)
+	if v, _ := q.Pop(0); v != "A" {
+		t.Fatalf("Pop(0) after Update = %q, want A", v)
+	}
+
+	eb, _ := q.Peek(0)
+	q.Remove(eb)
+	if q.Len() != 0 {
+		t.Fatalf("Len() = %d after Remove, want 0", q.Len())
+	}
+	if _, ok := q.Peek(0); ok {
+		t.Fatalf("Peek() on empty queue returned ok=true")
+	}
+}
+
+// TestLazyQueueRefresh checks that Refresh rebuilds one half at a time
+// without losing or duplicating elements.
+func TestLazyQueueRefresh(t *testing.T) {
+	q := NewLazyQueue[int, int](2)
+	for i := 0; i < 6; i++ {
+		v := i
+		q.Push(0, v,
+			func(now int) int { return v },
+			func(until int) int { return v },
+		)
+	}
+	q.Refresh(0)
+	q.Refresh(0)
+	if q.Len() != 6 {
+		t.Fatalf("Len() = %d after two Refresh calls, want 6", q.Len())
+	}
+
+	var popped []int
+	for q.Len() > 0 {
+		v, _ := q.Pop(0)
+		popped = append(popped, v)
+	}
+	for i, v := range popped {
+		if v != i {
+			t.Fatalf("popped[%d] = %d, want %d (ascending)", i, v, i)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+// Package render turns a scheduling run's Segments and Schedulables into
+// displayable bytes in one of several output formats, so results can be
+// shown in a terminal, piped into spreadsheets or plotting scripts, or
+// embedded as an SVG in slides and handouts.
+package render
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html"
+	"strings"
+
+	"opsys/pkg/sched"
+)
+
+// Renderer turns a completed scheduling run into displayable bytes.
+type Renderer interface {
+	RenderGantt(segments []sched.Segment) []byte
+	RenderStats(items []sched.Schedulable) []byte
+}
+
+// New looks up a Renderer by name: "text", "json", "csv", or "svg". An
+// empty string defaults to "text".
+func New(format string) (Renderer, error) {
+	switch format {
+	case "", "text":
+		return TextRenderer{}, nil
+	case "json":
+		return JSONRenderer{}, nil
+	case "csv":
+		return CSVRenderer{}, nil
+	case "svg":
+		return SVGRenderer{}, nil
+	default:
+		return nil, fmt.Errorf("render: unknown format %q", format)
+	}
+}
+
+// --- TextRenderer: the original fixed-width ASCII Gantt chart ---
+
+type TextRenderer struct{}
+
+func (TextRenderer) RenderGantt(segments []sched.Segment) []byte {
+	if len(segments) == 0 {
+		return []byte("(empty gantt)\n")
+	}
+	var scale, bars strings.Builder
+	last := segments[0].Start
+	for _, s := range segments {
+		if s.Start > last {
+			gap := s.Start - last
+			scale.WriteString(strings.Repeat("    ", gap))
+			bars.WriteString(strings.Repeat("    ", gap))
+		}
+		width := s.End - s.Start
+		cell := fmt.Sprintf("[%s]", centerString(s.Name, maxInt(1, width*2)))
+		bars.WriteString(cell)
+		scale.WriteString(fmt.Sprintf("%-4d", s.Start))
+		last = s.End
+	}
+	scale.WriteString(fmt.Sprintf("%-4d", segments[len(segments)-1].End))
+	var out bytes.Buffer
+	out.WriteString(scale.String())
+	out.WriteByte('\n')
+	out.WriteString(bars.String())
+	out.WriteByte('\n')
+	return out.Bytes()
+}
+
+func (TextRenderer) RenderStats(items []sched.Schedulable) []byte {
+	var out bytes.Buffer
+	for _, it := range items {
+		fmt.Fprintf(&out, "%-12s arrival=%-4d burst=%-4d start=%-4d finish=%-4d wait=%-4d tat=%-4d\n",
+			it.Name(), it.Arrival(), it.Burst(), it.Start(), it.Finish(), it.Wait(), it.Tat())
+	}
+	return out.Bytes()
+}
+
+func centerString(s string, width int) string {
+	if len(s) >= width {
+		return s[:width]
+	}
+	left := (width - len(s)) / 2
+	right := width - len(s) - left
+	return strings.Repeat(" ", left) + s + strings.Repeat(" ", right)
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+// --- JSONRenderer ---
+
+type JSONRenderer struct{}
+
+func (JSONRenderer) RenderGantt(segments []sched.Segment) []byte {
+	b, _ := json.MarshalIndent(segments, "", "  ")
+	return append(b, '\n')
+}
+
+type statRow struct {
+	Name    string `json:"name"`
+	Arrival int    `json:"arrival"`
+	Burst   int    `json:"burst"`
+	Start   int    `json:"start"`
+	Finish  int    `json:"finish"`
+	Wait    int    `json:"wait"`
+	Tat     int    `json:"tat"`
+}
+
+func statRows(items []sched.Schedulable) []statRow {
+	rows := make([]statRow, len(items))
+	for i, it := range items {
+		rows[i] = statRow{it.Name(), it.Arrival(), it.Burst(), it.Start(), it.Finish(), it.Wait(), it.Tat()}
+	}
+	return rows
+}
+
+func (JSONRenderer) RenderStats(items []sched.Schedulable) []byte {
+	b, _ := json.MarshalIndent(statRows(items), "", "  ")
+	return append(b, '\n')
+}
+
+// --- CSVRenderer ---
+
+type CSVRenderer struct{}
+
+func (CSVRenderer) RenderGantt(segments []sched.Segment) []byte {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"start", "end", "name"})
+	for _, s := range segments {
+		w.Write([]string{fmt.Sprint(s.Start), fmt.Sprint(s.End), s.Name})
+	}
+	w.Flush()
+	return buf.Bytes()
+}
+
+func (CSVRenderer) RenderStats(items []sched.Schedulable) []byte {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write([]string{"name", "arrival", "burst", "start", "finish", "wait", "tat"})
+	for _, it := range items {
+		w.Write([]string{
+			it.Name(), fmt.Sprint(it.Arrival()), fmt.Sprint(it.Burst()),
+			fmt.Sprint(it.Start()), fmt.Sprint(it.Finish()), fmt.Sprint(it.Wait()), fmt.Sprint(it.Tat()),
+		})
+	}
+	w.Flush()
+	return buf.Bytes()
+}
+
+// --- SVGRenderer ---
+
+// SVGRenderer draws proportional-width colored rectangles with a time
+// axis underneath; zero-valued PxPerTick/RowHeight fall back to sane
+// defaults.
+type SVGRenderer struct {
+	PxPerTick int
+	RowHeight int
+}
+
+var svgPalette = []string{
+	"#4e79a7", "#f28e2b", "#e15759", "#76b7b2",
+	"#59a14f", "#edc948", "#b07aa1", "#ff9da7",
+}
+
+func (r SVGRenderer) RenderGantt(segments []sched.Segment) []byte {
+	pxPerTick := r.PxPerTick
+	if pxPerTick == 0 {
+		pxPerTick = 24
+	}
+	rowHeight := r.RowHeight
+	if rowHeight == 0 {
+		rowHeight = 32
+	}
+	maxEnd := 0
+	colors := map[string]string{}
+	for _, s := range segments {
+		if s.End > maxEnd {
+			maxEnd = s.End
+		}
+		if _, ok := colors[s.Name]; !ok {
+			colors[s.Name] = svgPalette[len(colors)%len(svgPalette)]
+		}
+	}
+	width := maxEnd*pxPerTick + 20
+	height := rowHeight + 40
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" font-family="monospace" font-size="12">`+"\n", width, height)
+	for _, s := range segments {
+		x := s.Start*pxPerTick + 10
+		w := (s.End - s.Start) * pxPerTick
+		fmt.Fprintf(&buf, `<rect x="%d" y="10" width="%d" height="%d" fill="%s" stroke="black"/>`+"\n", x, w, rowHeight, colors[s.Name])
+		fmt.Fprintf(&buf, `<text x="%d" y="%d" text-anchor="middle">%s</text>`+"\n", x+w/2, 10+rowHeight/2+4, html.EscapeString(s.Name))
+	}
+	for t := 0; t <= maxEnd; t++ {
+		x := t*pxPerTick + 10
+		fmt.Fprintf(&buf, `<text x="%d" y="%d" text-anchor="middle">%d</text>`+"\n", x, 10+rowHeight+16, t)
+	}
+	buf.WriteString("</svg>\n")
+	return buf.Bytes()
+}
+
+// RenderStats has no natural timeline shape to draw, so SVGRenderer
+// falls back to the same plain text table as TextRenderer.
+func (SVGRenderer) RenderStats(items []sched.Schedulable) []byte {
+	return TextRenderer{}.RenderStats(items)
+}
@@ -0,0 +1,92 @@
+package render
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"opsys/pkg/sched"
+)
+
+func sampleSegments() []sched.Segment {
+	return []sched.Segment{
+		{Start: 0, End: 5, Name: "1-A"},
+		{Start: 5, End: 8, Name: "2-B"},
+	}
+}
+
+func sampleItems() []sched.Schedulable {
+	a := sched.NewPcb(1, "A", 0, 5)
+	a.SetStart(0)
+	a.SetFinish(5)
+	b := sched.NewPcb(2, "B", 1, 3)
+	b.SetStart(5)
+	b.SetFinish(8)
+	return []sched.Schedulable{a, b}
+}
+
+func TestNewUnknownFormat(t *testing.T) {
+	if _, err := New("bogus"); err == nil {
+		t.Fatal("New(\"bogus\") should have returned an error")
+	}
+}
+
+func TestJSONRendererShape(t *testing.T) {
+	r := JSONRenderer{}
+	var segs []sched.Segment
+	if err := json.Unmarshal(r.RenderGantt(sampleSegments()), &segs); err != nil {
+		t.Fatalf("RenderGantt did not produce valid JSON: %v", err)
+	}
+	if len(segs) != 2 || segs[0].Name != "1-A" {
+		t.Fatalf("unexpected decoded segments: %+v", segs)
+	}
+
+	var rows []statRow
+	if err := json.Unmarshal(r.RenderStats(sampleItems()), &rows); err != nil {
+		t.Fatalf("RenderStats did not produce valid JSON: %v", err)
+	}
+	if len(rows) != 2 || rows[0].Name != "1-A" || rows[0].Finish != 5 {
+		t.Fatalf("unexpected decoded rows: %+v", rows)
+	}
+}
+
+func TestCSVRendererShape(t *testing.T) {
+	r := CSVRenderer{}
+	recs, err := csv.NewReader(strings.NewReader(string(r.RenderGantt(sampleSegments())))).ReadAll()
+	if err != nil {
+		t.Fatalf("RenderGantt did not produce valid CSV: %v", err)
+	}
+	if len(recs) != 3 || recs[0][2] != "name" || recs[1][2] != "1-A" {
+		t.Fatalf("unexpected gantt CSV: %v", recs)
+	}
+
+	recs, err = csv.NewReader(strings.NewReader(string(r.RenderStats(sampleItems())))).ReadAll()
+	if err != nil {
+		t.Fatalf("RenderStats did not produce valid CSV: %v", err)
+	}
+	if len(recs) != 3 || recs[0][0] != "name" || recs[1][0] != "1-A" {
+		t.Fatalf("unexpected stats CSV: %v", recs)
+	}
+}
+
+func TestSVGRendererShape(t *testing.T) {
+	out := string(SVGRenderer{}.RenderGantt(sampleSegments()))
+	if !strings.HasPrefix(out, "<svg ") || !strings.HasSuffix(out, "</svg>\n") {
+		t.Fatalf("RenderGantt did not produce an svg document: %q", out)
+	}
+	if !strings.Contains(out, ">1-A<") || !strings.Contains(out, ">2-B<") {
+		t.Fatalf("RenderGantt missing expected segment labels: %q", out)
+	}
+}
+
+func TestSVGRendererEscapesNames(t *testing.T) {
+	segs := []sched.Segment{{Start: 0, End: 1, Name: "<script>&\"'"}}
+	out := string(SVGRenderer{}.RenderGantt(segs))
+	if strings.Contains(out, "<script>") {
+		t.Fatalf("process name was not escaped: %q", out)
+	}
+	if !strings.Contains(out, "&lt;script&gt;&amp;&#34;&#39;") {
+		t.Fatalf("expected escaped name in output: %q", out)
+	}
+}
@@ -0,0 +1,172 @@
+package sched
+
+import (
+	"fmt"
+	"sort"
+)
+
+// QueueConfig describes one level of an MLFQConfig: its time quantum and
+// whether it dispatches in round-robin (RR true) or run-to-completion
+// FCFS order (RR false, Quantum is then ignored).
+type QueueConfig struct {
+	Quantum int
+	RR      bool
+}
+
+// MLFQConfig configures a multi-level feedback queue: one QueueConfig
+// per level, highest priority first, plus an optional periodic priority
+// boost that resets every process back to level 0.
+type MLFQConfig struct {
+	Queues        []QueueConfig
+	BoostInterval int // every BoostInterval ticks, reset all levels to 0; 0 disables boosting
+}
+
+// RoundRobin is the degenerate single-queue case of MLFQ: every process
+// shares one RR level with the given quantum, so nothing ever demotes.
+func RoundRobin(items []Schedulable, quantum int) ([]Segment, []string) {
+	return MLFQ(items, MLFQConfig{Queues: []QueueConfig{{Quantum: quantum, RR: true}}})
+}
+
+// MLFQ schedules *Pcb items through cfg.Queues: a process starts at level
+// 0 and, if it ever uses its full quantum without finishing, drops one
+// level (staying at the bottom level once there); cfg.BoostInterval
+// periodically moves everyone back to level 0 to avoid starvation.
+// Segments are emitted per quantum slice, so the Gantt chart shows every
+// preemption point.
+func MLFQ(items []Schedulable, cfg MLFQConfig) ([]Segment, []string) {
+	if len(items) == 0 {
+		return nil, []string{"No items to schedule"}
+	}
+	if len(cfg.Queues) == 0 {
+		return nil, []string{"MLFQConfig requires at least one queue"}
+	}
+	pcbs := make([]*Pcb, len(items))
+	for i := range items {
+		p, ok := items[i].(*Pcb)
+		if !ok || p == nil {
+			return nil, []string{fmt.Sprintf("MLFQ requires *Pcb at index %d", i)}
+		}
+		pcbs[i] = p
+	}
+	sort.Slice(pcbs, func(i, j int) bool {
+		if pcbs[i].Arrival() != pcbs[j].Arrival() {
+			return pcbs[i].Arrival() < pcbs[j].Arrival()
+		}
+		return pcbs[i].Name() < pcbs[j].Name()
+	})
+
+	levels := make([][]*Pcb, len(cfg.Queues))
+	nextIdx := 0
+	arrive := func(now int) {
+		for nextIdx < len(pcbs) && pcbs[nextIdx].Arrival() <= now {
+			levels[0] = append(levels[0], pcbs[nextIdx])
+			nextIdx++
+		}
+	}
+
+	var (
+		gantt     []Segment
+		logs      []string
+		completed = 0
+		lastBoost = 0
+	)
+	maybeBoost := func(now int) {
+		if cfg.BoostInterval > 0 && now > 0 && now-lastBoost >= cfg.BoostInterval {
+			for lvl := 1; lvl < len(levels); lvl++ {
+				levels[0] = append(levels[0], levels[lvl]...)
+				levels[lvl] = nil
+			}
+			lastBoost = now
+		}
+	}
+	t := pcbs[0].Arrival()
+	for completed < len(pcbs) {
+		arrive(t)
+		maybeBoost(t)
+		lvl := -1
+		for i := range levels {
+			if len(levels[i]) > 0 {
+				lvl = i
+				break
+			}
+		}
+		if lvl == -1 {
+			if nextIdx < len(pcbs) {
+				t = pcbs[nextIdx].Arrival()
+				continue
+			}
+			break
+		}
+
+		q := cfg.Queues[lvl]
+		curr := levels[lvl][0]
+		levels[lvl] = levels[lvl][1:]
+		curr.SetStart(t)
+
+		// intended is how long curr would run if nothing interrupted it:
+		// its whole remaining burst on an FCFS level, or one quantum on RR.
+		// The actual run is capped at whichever of those limits comes
+		// first, so a higher-level arrival or a priority boost can always
+		// break in mid-slice instead of only being noticed once the
+		// intended run has already elapsed.
+		intended := curr.Remain
+		if q.RR && q.Quantum > 0 {
+			intended = min(q.Quantum, curr.Remain)
+		}
+		run := intended
+		if lvl > 0 && nextIdx < len(pcbs) {
+			// Arrivals always join level 0, so they can only preempt a
+			// process running at a lower (higher-index) level. A process
+			// already at the top level isn't outranked by a same-level
+			// arrival, which just queues behind it instead.
+			run = min(run, pcbs[nextIdx].Arrival()-t)
+		}
+		if cfg.BoostInterval > 0 {
+			run = min(run, lastBoost+cfg.BoostInterval-t)
+		}
+		if run <= 0 {
+			run = 1
+		}
+
+		param := &PreemptArgs{t, t + run, run}
+		gantt, logs = resOut(curr, gantt, logs, param)
+		t += run
+		curr.Remain -= run
+
+		// Pull in anything that arrived during this slice before curr
+		// rejoins a queue: an arrival that showed up while curr was
+		// running got to the queue first and should be ordered ahead of
+		// it, not behind it just because curr's requeue is processed
+		// first. The boost check stays at the top of the loop, since a
+		// boost applies to whatever is queued at the next dispatch, not
+		// to curr's own placement below.
+		arrive(t)
+
+		if curr.Remain == 0 {
+			curr.SetFinish(t)
+			gantt, logs = resOut(curr, gantt, logs, param)
+			completed++
+			continue
+		}
+		if run < intended {
+			// Preempted before using its full slice: resume at the front
+			// of the same level next time it's dispatched, rather than
+			// being demoted for a slice it never got to finish.
+			levels[lvl] = append([]*Pcb{curr}, levels[lvl]...)
+			continue
+		}
+		next := lvl
+		if lvl < len(levels)-1 {
+			next = lvl + 1
+		}
+		levels[next] = append(levels[next], curr)
+	}
+	return coalesce(gantt), logs
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,88 @@
+package sched
+
+import "testing"
+
+// TestMLFQPreemptsAcrossLevels reproduces the scenario where a process
+// demoted to a lower FCFS level must still be preempted the instant a
+// higher-level process arrives, instead of running to the end of its
+// current slice.
+func TestMLFQPreemptsAcrossLevels(t *testing.T) {
+	a := NewPcb(1, "A", 0, 10)
+	b := NewPcb(2, "B", 5, 1)
+
+	cfg := MLFQConfig{
+		Queues: []QueueConfig{
+			{Quantum: 2, RR: true},
+			{Quantum: 0, RR: false},
+		},
+	}
+	MLFQ([]Schedulable{a, b}, cfg)
+
+	if b.Start() != 5 {
+		t.Errorf("B should start the instant it arrives at t=5, got start=%d", b.Start())
+	}
+	if b.Finish() != 6 {
+		t.Errorf("B (burst=1) should finish at t=6, got finish=%d", b.Finish())
+	}
+	if a.Finish() != 11 {
+		t.Errorf("A should finish at t=11 once B is done, got finish=%d", a.Finish())
+	}
+}
+
+// TestMLFQBoostMidSlice checks that a priority boost due mid-slice takes
+// effect at the boost tick rather than being delayed until the running
+// process's slice ends.
+func TestMLFQBoostMidSlice(t *testing.T) {
+	a := NewPcb(1, "A", 0, 20)
+	b := NewPcb(2, "B", 0, 1)
+
+	cfg := MLFQConfig{
+		Queues: []QueueConfig{
+			{Quantum: 100, RR: true},
+		},
+		BoostInterval: 5,
+	}
+	// Add a second, FCFS level below the first. A's 100-tick quantum covers
+	// its whole burst, so only the boost at t=5 can cut its first slice.
+	cfg.Queues = append(cfg.Queues, QueueConfig{Quantum: 0, RR: false})
+	_, _ = MLFQ([]Schedulable{a, b}, cfg)
+
+	if a.restarts[0] != 0 {
+		t.Fatalf("expected A to start at t=0, got %d", a.restarts[0])
+	}
+	// A's first slice must stop at the boost tick (t=5), not run uninterrupted.
+	found := false
+	for _, r := range a.restarts {
+		if r == 5 {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected A to be redispatched at the boost tick t=5, restarts=%v", a.restarts)
+	}
+}
+
+// TestRoundRobinSameLevelArrivalDoesNotPreempt checks that an arrival
+// joining the same level as the process currently running doesn't cut its
+// quantum short — only a strictly higher level should preempt. The
+// arriving process should still be fairly queued ahead of the process
+// whose quantum it arrived during, once that quantum ends.
+func TestRoundRobinSameLevelArrivalDoesNotPreempt(t *testing.T) {
+	a := NewPcb(1, "A", 0, 10)
+	c := NewPcb(2, "C", 2, 1)
+
+	gantt, _ := RoundRobin([]Schedulable{a, c}, 5)
+
+	if len(gantt) == 0 || gantt[0].Name != a.Name() || gantt[0].Start != 0 || gantt[0].End != 5 {
+		t.Fatalf("expected A's first quantum to run uninterrupted [0,5), got %+v", gantt)
+	}
+	if c.Start() != 5 {
+		t.Errorf("C should wait for A's quantum to finish before running, got start=%d", c.Start())
+	}
+	if c.Finish() != 6 {
+		t.Errorf("C (burst=1) should finish right after it runs, got finish=%d", c.Finish())
+	}
+	if a.Finish() != 11 {
+		t.Errorf("A should finish at t=11 after its second quantum, got finish=%d", a.Finish())
+	}
+}
@@ -0,0 +1,581 @@
+// Package sched holds the scheduling domain types and algorithms
+// (FCFS/SJF/SRTF/Priority) so they can be shared by more than one
+// front end (the batch CLI and the interactive TUI).
+package sched
+
+import (
+	"fmt"
+	"sort"
+
+	"opsys/pkg/prque"
+)
+
+// --- Gantt chart data ---
+
+type Segment struct {
+	Start int
+	End   int
+	Name  string
+}
+
+// --- Schedulable object ---
+
+type Schedulable interface {
+	Name() string
+	Arrival() int
+	Burst() int
+	Start() int
+	Finish() int
+	SetStart(int)
+	SetFinish(int)
+	ComputeStats()
+	Wait() int
+	Tat() int
+}
+
+type InputInfo struct {
+	id      int
+	name    string
+	Arrival int
+	Burst   int
+}
+
+func (i InputInfo) Name() string {
+	return fmt.Sprintf("%d-%s", i.id, i.name)
+}
+
+type BaseInfo struct {
+	InputInfo
+	start  int
+	finish int
+	wait   int
+	tat    int
+}
+
+func NewBaseInfo(id int, name string, arrival int, burst int) BaseInfo {
+	return BaseInfo{
+		InputInfo: InputInfo{
+			id:      id,
+			name:    name,
+			Arrival: arrival,
+			Burst:   burst,
+		},
+		start:  -1,
+		finish: -1,
+		wait:   -1,
+		tat:    -1,
+	}
+}
+
+func (b BaseInfo) Arrival() int { return b.InputInfo.Arrival }
+func (b BaseInfo) Burst() int   { return b.InputInfo.Burst }
+func (b BaseInfo) Start() int   { return b.start }
+func (b BaseInfo) Finish() int  { return b.finish }
+func (b BaseInfo) Wait() int    { return b.wait }
+func (b BaseInfo) Tat() int     { return b.tat }
+
+// RawName returns the name given at construction, without the "id-"
+// prefix Name() adds; useful for callers that need to rebuild an
+// equivalent item (e.g. workload.Benchmark cloning a workload per algo).
+func (b BaseInfo) RawName() string { return b.InputInfo.name }
+
+type Job struct {
+	BaseInfo
+	Priority int
+}
+
+func NewJob(id int, name string, arrival int, burst int) *Job {
+	return &Job{
+		BaseInfo: NewBaseInfo(id, name, arrival, burst),
+		Priority: 0,
+	}
+}
+
+func (j *Job) SetStart(s int) { j.start = s }
+func (j *Job) SetFinish(f int) {
+	j.finish = f
+	j.ComputeStats()
+}
+func (j *Job) ComputeStats() {
+	if j.finish >= 0 {
+		j.tat = j.finish - j.Arrival()
+		j.wait = j.tat - j.Burst()
+		if j.wait < 0 {
+			j.wait = 0
+		}
+	}
+}
+
+func (j *Job) SetPriority(p int) { j.Priority = p }
+
+type Pcb struct {
+	BaseInfo
+	Remain   int
+	Priority int
+	restarts []int
+}
+
+func NewPcb(id int, name string, arrival int, burst int) *Pcb {
+	return &Pcb{
+		BaseInfo: NewBaseInfo(id, name, arrival, burst),
+		Remain:   burst,
+		Priority: 0,
+		restarts: nil,
+	}
+}
+
+func (p *Pcb) SetPriority(pr int) { p.Priority = pr }
+
+func (p *Pcb) SetStart(s int) {
+	if p.start == -1 {
+		p.start = s
+	}
+	p.restarts = append(p.restarts, s)
+}
+func (p *Pcb) SetFinish(f int) {
+	p.finish = f
+	p.Remain = 0
+	p.ComputeStats()
+}
+func (p *Pcb) ComputeStats() {
+	if p.finish >= 0 {
+		p.tat = p.finish - p.Arrival()
+		p.wait = p.tat - p.Burst()
+		if p.wait < 0 {
+			p.wait = 0
+		}
+	}
+}
+
+// Schedulable interface compliance checks
+var _ Schedulable = (*Job)(nil)
+var _ Schedulable = (*Pcb)(nil)
+
+// --- Scheduler interface ---
+
+// Scheduler exposes one simulation step at a time instead of only a
+// final result, so a front end (e.g. cmd/opsys-tui) can draw the Gantt
+// chart, ready queue, and running PCB as they change, rather than only
+// after the whole run has been computed.
+type Scheduler interface {
+	// Tick advances the simulation by one time unit starting at now and
+	// returns the Segment that ran during [now, now+1), or false if the
+	// CPU was idle because nothing had arrived yet.
+	Tick(now int) (Segment, bool)
+	// Enqueue makes an item known to the scheduler; it is only considered
+	// once Tick reaches its Arrival().
+	Enqueue(item Schedulable)
+}
+
+// npScheduler runs whichever arrived item `pick` selects to completion,
+// one tick at a time. FCFS and SJF are both this scheduler with a
+// different pick function.
+type npScheduler struct {
+	pending []Schedulable
+	ready   []Schedulable
+	curr    Schedulable
+	pick    func(ready []Schedulable) int
+}
+
+func newNpScheduler(pick func([]Schedulable) int) *npScheduler {
+	return &npScheduler{pick: pick}
+}
+
+func (s *npScheduler) Enqueue(item Schedulable) {
+	s.pending = append(s.pending, item)
+}
+
+func (s *npScheduler) Tick(now int) (Segment, bool) {
+	for i := 0; i < len(s.pending); {
+		if s.pending[i].Arrival() <= now {
+			s.ready = append(s.ready, s.pending[i])
+			s.pending = append(s.pending[:i], s.pending[i+1:]...)
+			continue
+		}
+		i++
+	}
+	if s.curr == nil {
+		if len(s.ready) == 0 {
+			return Segment{}, false
+		}
+		idx := s.pick(s.ready)
+		s.curr = s.ready[idx]
+		s.ready = append(s.ready[:idx], s.ready[idx+1:]...)
+		s.curr.SetStart(now)
+	}
+	seg := Segment{Start: now, End: now + 1, Name: s.curr.Name()}
+	if now+1 >= s.curr.Start()+s.curr.Burst() {
+		s.curr.SetFinish(s.curr.Start() + s.curr.Burst())
+		s.curr = nil
+	}
+	return seg, true
+}
+
+// srtfScheduler keeps every arrived, unfinished Pcb resident in a
+// LazyQueue keyed by remaining time, so whichever process has the shortest remaining
+// time is always the Peek head; preemption falls out of re-peeking every
+// tick instead of a dedicated check.
+type srtfScheduler struct {
+	pending []*Pcb
+	queue   *prque.LazyQueue[int, *Pcb]
+	elems   map[*Pcb]*prque.Element[int, *Pcb]
+}
+
+func newSrtfScheduler() *srtfScheduler {
+	return &srtfScheduler{
+		queue: prque.NewLazyQueue[int, *Pcb](1),
+		elems: map[*Pcb]*prque.Element[int, *Pcb]{},
+	}
+}
+
+func (s *srtfScheduler) Enqueue(item Schedulable) {
+	if p, ok := item.(*Pcb); ok {
+		s.pending = append(s.pending, p)
+	}
+}
+
+func (s *srtfScheduler) Tick(now int) (Segment, bool) {
+	for i := 0; i < len(s.pending); {
+		if s.pending[i].Arrival() <= now {
+			p := s.pending[i]
+			remain := func(int) int { return p.Remain }
+			s.elems[p] = s.queue.Push(now, p, remain, remain)
+			s.pending = append(s.pending[:i], s.pending[i+1:]...)
+			continue
+		}
+		i++
+	}
+	head, ok := s.queue.Peek(now)
+	if !ok {
+		return Segment{}, false
+	}
+	curr := head.Value
+	if curr.Start() == -1 {
+		curr.SetStart(now)
+	}
+	curr.Remain--
+	if curr.Remain == 0 {
+		s.queue.Remove(head)
+		delete(s.elems, curr)
+		curr.SetFinish(now + 1)
+	} else {
+		s.queue.Update(now, head)
+	}
+	return Segment{Start: now, End: now + 1, Name: curr.Name()}, true
+}
+
+// drain runs sched tick by tick, starting at the earliest arrival among
+// items, until every item has finished, collapsing the per-tick Segments
+// back into the batch ([]Segment, []string) shape the original
+// algorithms returned.
+func drain(sched Scheduler, items []Schedulable) ([]Segment, []string) {
+	t := items[0].Arrival()
+	for _, it := range items {
+		if it.Arrival() < t {
+			t = it.Arrival()
+		}
+	}
+	var gantt []Segment
+	var logs []string
+	left := len(items)
+	for left > 0 {
+		seg, ok := sched.Tick(t)
+		if ok {
+			gantt = append(gantt, seg)
+			for _, it := range items {
+				if it.Finish() == seg.End && it.Name() == seg.Name {
+					logs = append(logs, fmt.Sprintf("t=%d: Run %s (burst=%d) -> Finish=%d", it.Start(), it.Name(), it.Burst(), it.Finish()))
+					left--
+				}
+			}
+		}
+		t++
+	}
+	return coalesce(gantt), logs
+}
+
+// --- Scheduling algorithms ---
+
+// NewFCFSScheduler returns a Scheduler that runs arrived items in
+// arrival order. Front ends that want to drive the simulation tick by
+// tick (e.g. cmd/opsys-tui) use this directly instead of calling FCFS
+// for a final result.
+func NewFCFSScheduler() Scheduler {
+	return newNpScheduler(func(ready []Schedulable) int {
+		best := 0
+		for i, it := range ready {
+			if it.Arrival() < ready[best].Arrival() || (it.Arrival() == ready[best].Arrival() && it.Name() < ready[best].Name()) {
+				best = i
+			}
+		}
+		return best
+	})
+}
+
+// NewSJFScheduler returns a Scheduler that always runs the arrived item
+// with the shortest burst to completion.
+func NewSJFScheduler() Scheduler {
+	return newNpScheduler(func(ready []Schedulable) int {
+		best := 0
+		for i, it := range ready {
+			if it.Burst() < ready[best].Burst() {
+				best = i
+			}
+		}
+		return best
+	})
+}
+
+// NewSRTFScheduler returns a preemptive Scheduler that always runs
+// whichever *Pcb has the least remaining time.
+func NewSRTFScheduler() Scheduler {
+	return newSrtfScheduler()
+}
+
+func FCFS(items []Schedulable) ([]Segment, []string) {
+	if len(items) == 0 {
+		return nil, []string{"No items to schedule"}
+	}
+	sched := NewFCFSScheduler()
+	for _, it := range items {
+		sched.Enqueue(it)
+	}
+	return drain(sched, items)
+}
+
+func SJF(items []Schedulable) ([]Segment, []string) {
+	if len(items) == 0 {
+		return nil, []string{"No items to schedule"}
+	}
+	sched := NewSJFScheduler()
+	for _, it := range items {
+		sched.Enqueue(it)
+	}
+	return drain(sched, items)
+}
+
+func SRTF(items []Schedulable) ([]Segment, []string) {
+	if len(items) == 0 {
+		return nil, []string{"No items to schedule"}
+	}
+	for i := range items {
+		if _, ok := items[i].(*Pcb); !ok {
+			return nil, []string{fmt.Sprintf("SRTF requires *Pcb at index %d", i)}
+		}
+	}
+	sched := NewSRTFScheduler()
+	for _, it := range items {
+		sched.Enqueue(it)
+	}
+	return drain(sched, items)
+}
+
+// --- Priority scheduling ---
+
+// priorityOf reads the scheduling priority off the concrete Schedulable,
+// since neither Job nor Pcb exposes it through the interface. Lower
+// values mean higher priority, matching Job.Priority's existing meaning.
+func priorityOf(s Schedulable) int {
+	switch v := s.(type) {
+	case *Job:
+		return v.Priority
+	case *Pcb:
+		return v.Priority
+	default:
+		return 0
+	}
+}
+
+// PriorityNP is non-preemptive priority scheduling: among arrived items,
+// always run the one with the lowest Priority next, letting it run to
+// completion. It is the priority-keyed sibling of SJF.
+func PriorityNP(items []Schedulable) ([]Segment, []string) {
+	if len(items) == 0 {
+		return nil, []string{"No items to schedule"}
+	}
+	copied := make([]Schedulable, len(items))
+	copy(copied, items)
+	sort.Slice(copied, func(i, j int) bool {
+		if copied[i].Arrival() != copied[j].Arrival() {
+			return copied[i].Arrival() < copied[j].Arrival()
+		}
+		return copied[i].Name() < copied[j].Name()
+	})
+	var (
+		gantt      []Segment
+		logs       []string
+		remaining  = len(copied)
+		nextIdx    = 0
+		readyQueue []Schedulable
+	)
+	t := copied[0].Arrival()
+	for remaining > 0 {
+		for nextIdx < len(copied) && copied[nextIdx].Arrival() <= t {
+			readyQueue = append(readyQueue, copied[nextIdx])
+			nextIdx++
+		}
+		if len(readyQueue) == 0 {
+			if nextIdx < len(copied) {
+				t = copied[nextIdx].Arrival()
+				continue
+			}
+			break
+		}
+		sort.SliceStable(readyQueue, func(i, j int) bool { return priorityOf(readyQueue[i]) < priorityOf(readyQueue[j]) })
+		currIt := readyQueue[0]
+		readyQueue = readyQueue[1:]
+		startT := t
+		currIt.SetStart(startT)
+		finishT := startT + currIt.Burst()
+		currIt.SetFinish(finishT)
+		gantt, logs = resOut(currIt, gantt, logs, nil)
+		t = currIt.Finish()
+		remaining--
+	}
+	return gantt, logs
+}
+
+// PriorityP is preemptive priority scheduling with optional aging: every
+// agingInterval ticks a ready-but-not-running task's effective priority
+// improves by agingRate, so it can eventually preempt whatever is
+// running; a task's priority is only aged while it waits and reverts to
+// its original value the moment it runs. Set agingRate to 0 to disable
+// aging. It requires *Pcb items, like SRTF, since it tracks remaining
+// burst and context switches.
+func PriorityP(items []Schedulable, agingRate int, agingInterval int) ([]Segment, []string) {
+	if len(items) == 0 {
+		return nil, []string{"No items to schedule"}
+	}
+	pcbs := make([]*Pcb, len(items))
+	for i := range items {
+		p, ok := items[i].(*Pcb)
+		if !ok || p == nil {
+			return nil, []string{fmt.Sprintf("PriorityP requires *Pcb at index %d", i)}
+		}
+		pcbs[i] = p
+	}
+	sort.Slice(pcbs, func(i, j int) bool {
+		if pcbs[i].Arrival() != pcbs[j].Arrival() {
+			return pcbs[i].Arrival() < pcbs[j].Arrival()
+		}
+		return pcbs[i].Name() < pcbs[j].Name()
+	})
+
+	horizon := agingInterval
+	if horizon <= 0 {
+		horizon = 1
+	}
+	queue := prque.NewLazyQueue[int, *Pcb](horizon)
+	queuedAt := make(map[*Pcb]int, len(pcbs))
+	effective := func(p *Pcb, now int) int {
+		if agingRate <= 0 || agingInterval <= 0 {
+			return p.Priority
+		}
+		waited := now - queuedAt[p]
+		if waited <= 0 {
+			return p.Priority
+		}
+		return p.Priority - (waited/agingInterval)*agingRate
+	}
+	enqueue := func(p *Pcb, now int) {
+		queuedAt[p] = now
+		queue.Push(now, p,
+			func(n int) int { return effective(p, n) },
+			func(until int) int { return effective(p, until) },
+		)
+	}
+
+	var (
+		gantt       []Segment
+		logs        []string
+		nextIdx     = 0
+		completed   = 0
+		curr        *Pcb
+		prevRunning *Pcb
+	)
+	t := pcbs[0].Arrival()
+	for completed < len(pcbs) {
+		for nextIdx < len(pcbs) && pcbs[nextIdx].Arrival() <= t {
+			enqueue(pcbs[nextIdx], t)
+			nextIdx++
+		}
+		if curr != nil {
+			// curr was running with its un-aged Priority; putting it back
+			// resets its wait clock so it ages again from this point.
+			enqueue(curr, t)
+			curr = nil
+		}
+		if queue.Len() == 0 {
+			if nextIdx < len(pcbs) {
+				t = pcbs[nextIdx].Arrival()
+				prevRunning = nil
+				continue
+			}
+			break
+		}
+		if t%horizon == 0 {
+			queue.Refresh(t)
+		}
+		curr, _ = queue.Pop(t)
+		if curr != prevRunning {
+			curr.SetStart(t)
+		}
+		prevRunning = curr
+		param := &PreemptArgs{t, t + 1, 1}
+		gantt, logs = resOut(curr, gantt, logs, param)
+		t++
+		curr.Remain--
+		if curr.Remain == 0 {
+			curr.SetFinish(t)
+			gantt, logs = resOut(curr, gantt, logs, param)
+			completed++
+			curr = nil
+			prevRunning = nil
+		}
+	}
+	return coalesce(gantt), logs
+}
+
+// coalesce merges consecutive segments for the same process, turning the
+// tick-by-tick slices the Scheduler-driven algorithms emit into the same
+// single-bar look a batch run produces.
+func coalesce(g []Segment) []Segment {
+	if len(g) == 0 {
+		return g
+	}
+	out := g[:1]
+	for _, s := range g[1:] {
+		last := &out[len(out)-1]
+		if last.Name == s.Name && last.End == s.Start {
+			last.End = s.End
+			continue
+		}
+		out = append(out, s)
+	}
+	return out
+}
+
+// --- Helper functions ---
+
+type PreemptArgs struct {
+	Run, Pause, Duration int
+}
+
+func resOut(s Schedulable, g []Segment, l []string, param *PreemptArgs) ([]Segment, []string) {
+	if param == nil {
+		g = append(g, Segment{Start: s.Start(), End: s.Finish(), Name: s.Name()})
+		l = append(l, fmt.Sprintf("t=%d: Run %s (burst=%d) -> Finish=%d", s.Start(), s.Name(), s.Burst(), s.Finish()))
+	} else if p, ok := s.(*Pcb); ok {
+		if p.Finish() == -1 {
+			g = append(g, Segment{Start: param.Run, End: param.Pause, Name: p.Name()})
+			l = append(l, fmt.Sprintf(
+				"t=%d: %s runs %d unit(s). Remain %d -> %d",
+				param.Run, p.Name(), param.Duration, p.Remain, p.Remain-param.Duration,
+			))
+		} else {
+			l = append(l, fmt.Sprintf("t=%d: Process %s finished.", p.Finish(), p.Name()))
+			return g, l
+		}
+	}
+	return g, l
+}
@@ -0,0 +1,128 @@
+package workload
+
+import (
+	"fmt"
+	"strings"
+
+	"opsys/pkg/sched"
+)
+
+// SchedulerFn pairs a scheduling algorithm with the name shown for it in
+// a BenchmarkReport.
+type SchedulerFn struct {
+	Name string
+	Run  func(items []sched.Schedulable) ([]sched.Segment, []string)
+}
+
+// Row is one algorithm x workload result in a BenchmarkReport.
+type Row struct {
+	Algo             string
+	Workload         int
+	AvgWait          float64
+	AvgTat           float64
+	Throughput       float64 // completed items per tick of makespan
+	CPUUtilization   float64 // fraction of the makespan the CPU was busy
+	ResponseVariance float64 // variance of (Start - Arrival) across items
+}
+
+// BenchmarkReport is the table Benchmark produces: one Row per algorithm
+// run against each workload.
+type BenchmarkReport struct {
+	Rows []Row
+}
+
+// String renders the report as a simple aligned text table.
+func (r BenchmarkReport) String() string {
+	var out strings.Builder
+	fmt.Fprintf(&out, "%-12s %8s %10s %10s %12s %10s %12s\n", "algo", "workload", "avgWait", "avgTat", "throughput", "cpuUtil", "respVar")
+	for _, row := range r.Rows {
+		fmt.Fprintf(&out, "%-12s %8d %10.2f %10.2f %12.4f %10.2f %12.2f\n",
+			row.Algo, row.Workload, row.AvgWait, row.AvgTat, row.Throughput, row.CPUUtilization, row.ResponseVariance)
+	}
+	return out.String()
+}
+
+// Benchmark runs every algorithm against every workload and reports the
+// standard OS-course comparison metrics for each pairing. Each pairing
+// gets its own clone of the workload, since running an algorithm mutates
+// its items' Start/Finish in place.
+func Benchmark(algos []SchedulerFn, workloads [][]sched.Schedulable) BenchmarkReport {
+	var report BenchmarkReport
+	for wi, wl := range workloads {
+		for _, algo := range algos {
+			items := cloneWorkload(wl)
+			gantt, _ := algo.Run(items)
+			report.Rows = append(report.Rows, summarize(algo.Name, wi, items, gantt))
+		}
+	}
+	return report
+}
+
+// cloneWorkload rebuilds fresh *Pcb/*Job items with the same arrival,
+// burst and priority, so each algorithm run in Benchmark starts from an
+// unfinished workload.
+func cloneWorkload(items []sched.Schedulable) []sched.Schedulable {
+	out := make([]sched.Schedulable, len(items))
+	for i, it := range items {
+		switch v := it.(type) {
+		case *sched.Pcb:
+			p := sched.NewPcb(i+1, v.RawName(), v.Arrival(), v.Burst())
+			p.SetPriority(v.Priority)
+			out[i] = p
+		case *sched.Job:
+			j := sched.NewJob(i+1, v.RawName(), v.Arrival(), v.Burst())
+			j.SetPriority(v.Priority)
+			out[i] = j
+		default:
+			out[i] = it
+		}
+	}
+	return out
+}
+
+func summarize(algoName string, workloadIdx int, items []sched.Schedulable, gantt []sched.Segment) Row {
+	row := Row{Algo: algoName, Workload: workloadIdx}
+	n := len(items)
+	if n == 0 {
+		return row
+	}
+
+	minArrival, maxFinish := items[0].Arrival(), items[0].Finish()
+	responses := make([]float64, n)
+	var sumWait, sumTat float64
+	for i, it := range items {
+		sumWait += float64(it.Wait())
+		sumTat += float64(it.Tat())
+		responses[i] = float64(it.Start() - it.Arrival())
+		if it.Arrival() < minArrival {
+			minArrival = it.Arrival()
+		}
+		if it.Finish() > maxFinish {
+			maxFinish = it.Finish()
+		}
+	}
+	row.AvgWait = sumWait / float64(n)
+	row.AvgTat = sumTat / float64(n)
+
+	var meanResp float64
+	for _, r := range responses {
+		meanResp += r
+	}
+	meanResp /= float64(n)
+	var variance float64
+	for _, r := range responses {
+		d := r - meanResp
+		variance += d * d
+	}
+	row.ResponseVariance = variance / float64(n)
+
+	if makespan := maxFinish - minArrival; makespan > 0 {
+		busy := 0
+		for _, s := range gantt {
+			busy += s.End - s.Start
+		}
+		row.Throughput = float64(n) / float64(makespan)
+		row.CPUUtilization = float64(busy) / float64(makespan)
+	}
+	return row
+}
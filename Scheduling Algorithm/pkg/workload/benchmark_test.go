@@ -0,0 +1,45 @@
+package workload
+
+import (
+	"strings"
+	"testing"
+
+	"opsys/pkg/sched"
+)
+
+func TestBenchmarkProducesOneRowPerAlgoPerWorkload(t *testing.T) {
+	wl := []sched.Schedulable{
+		sched.NewPcb(1, "A", 0, 5),
+		sched.NewPcb(2, "B", 1, 3),
+	}
+	algos := []SchedulerFn{
+		{Name: "fcfs", Run: sched.FCFS},
+		{Name: "sjf", Run: sched.SJF},
+	}
+
+	report := Benchmark(algos, [][]sched.Schedulable{wl})
+	if len(report.Rows) != len(algos) {
+		t.Fatalf("len(Rows) = %d, want %d", len(report.Rows), len(algos))
+	}
+	for _, row := range report.Rows {
+		if row.AvgWait < 0 || row.AvgTat < 0 {
+			t.Errorf("row %+v has a negative wait/tat", row)
+		}
+		if row.Throughput <= 0 || row.CPUUtilization <= 0 {
+			t.Errorf("row %+v expected positive throughput/cpuUtil for a non-empty workload", row)
+		}
+	}
+
+	out := report.String()
+	if !strings.Contains(out, "fcfs") || !strings.Contains(out, "sjf") {
+		t.Fatalf("String() missing an algorithm name: %q", out)
+	}
+}
+
+func TestBenchmarkDoesNotMutateTheOriginalWorkload(t *testing.T) {
+	wl := []sched.Schedulable{sched.NewPcb(1, "A", 0, 5)}
+	Benchmark([]SchedulerFn{{Name: "fcfs", Run: sched.FCFS}}, [][]sched.Schedulable{wl})
+	if wl[0].Start() != -1 || wl[0].Finish() != -1 {
+		t.Fatalf("Benchmark mutated the caller's workload: start=%d finish=%d", wl[0].Start(), wl[0].Finish())
+	}
+}
@@ -0,0 +1,92 @@
+package workload
+
+import (
+	"math/rand"
+	"testing"
+
+	"opsys/pkg/sched"
+)
+
+func TestGenerateIsReproducible(t *testing.T) {
+	cfg := Config{N: 30, ArrivalRate: 0.5, BurstDist: Exponential, BurstMean: 4, MinPriority: 0, MaxPriority: 3, Seed: 42}
+	a := Generate(cfg)
+	b := Generate(cfg)
+	if len(a) != len(b) {
+		t.Fatalf("len mismatch: %d vs %d", len(a), len(b))
+	}
+	for i := range a {
+		pa, pb := a[i].(*sched.Pcb), b[i].(*sched.Pcb)
+		if pa.Arrival() != pb.Arrival() || pa.Burst() != pb.Burst() || pa.Priority != pb.Priority {
+			t.Fatalf("item %d differs between runs with the same seed: %+v vs %+v", i, pa, pb)
+		}
+	}
+}
+
+func TestGenerateDifferentSeedsDiffer(t *testing.T) {
+	cfg1 := Config{N: 30, ArrivalRate: 0.5, BurstDist: Exponential, BurstMean: 4, MaxPriority: 3, Seed: 1}
+	cfg2 := cfg1
+	cfg2.Seed = 2
+	a := Generate(cfg1)
+	b := Generate(cfg2)
+	same := true
+	for i := range a {
+		if a[i].Arrival() != b[i].Arrival() || a[i].Burst() != b[i].Burst() {
+			same = false
+			break
+		}
+	}
+	if same {
+		t.Fatal("workloads from different seeds were identical")
+	}
+}
+
+func TestGenerateInvariants(t *testing.T) {
+	cfg := Config{N: 100, ArrivalRate: 0.3, BurstDist: Pareto, BurstMean: 5, ParetoShape: 2, MinPriority: 1, MaxPriority: 4, Seed: 7}
+	items := Generate(cfg)
+	if len(items) != cfg.N {
+		t.Fatalf("len(items) = %d, want %d", len(items), cfg.N)
+	}
+	lastArrival := -1
+	for _, it := range items {
+		if it.Arrival() < lastArrival {
+			t.Fatalf("arrivals not non-decreasing: %d after %d", it.Arrival(), lastArrival)
+		}
+		lastArrival = it.Arrival()
+		if it.Burst() < 1 {
+			t.Fatalf("burst %d below the 1-tick floor", it.Burst())
+		}
+		p := it.(*sched.Pcb)
+		if p.Priority < cfg.MinPriority || p.Priority > cfg.MaxPriority {
+			t.Fatalf("priority %d outside [%d,%d]", p.Priority, cfg.MinPriority, cfg.MaxPriority)
+		}
+	}
+}
+
+func TestUniformPriorityDegenerateRange(t *testing.T) {
+	cfg := Config{MinPriority: 3, MaxPriority: 3}
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 10; i++ {
+		if got := uniformPriority(rng, cfg); got != 3 {
+			t.Fatalf("uniformPriority with MaxPriority == MinPriority = %d, want 3", got)
+		}
+	}
+
+	cfg.MaxPriority = 1 // MaxPriority < MinPriority
+	for i := 0; i < 10; i++ {
+		if got := uniformPriority(rng, cfg); got != 3 {
+			t.Fatalf("uniformPriority with MaxPriority < MinPriority = %d, want MinPriority 3", got)
+		}
+	}
+}
+
+func TestSampleBurstFloorsAtOne(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, dist := range []BurstDistribution{Exponential, Pareto} {
+		cfg := Config{BurstDist: dist, BurstMean: 0.0001, ParetoShape: 100}
+		for i := 0; i < 50; i++ {
+			if b := sampleBurst(rng, cfg); b < 1 {
+				t.Fatalf("sampleBurst(dist=%v) = %d, want >= 1", dist, b)
+			}
+		}
+	}
+}